@@ -0,0 +1,349 @@
+package broker
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/cloudstax/openmanage/catalog"
+	"github.com/cloudstax/openmanage/common"
+	"github.com/cloudstax/openmanage/manage"
+	"github.com/cloudstax/openmanage/manage/client"
+)
+
+const (
+	stateInProgress = "in progress"
+	stateSucceeded  = "succeeded"
+	stateFailed     = "failed"
+)
+
+type instanceState struct {
+	serviceType string
+	state       string
+	description string
+
+	// adminUser/adminPasswd are the admin credentials createAndWait asked
+	// the catalog service to create, if any, so bind can return the
+	// credentials that actually exist instead of a hardcoded guess.
+	adminUser   string
+	adminPasswd string
+}
+
+// adminCredentialsFor returns the admin user/password that createAndWait
+// creates a new serviceType instance with. Every catalog service type
+// created through the broker gets an admin account named "dbadmin", except
+// PostgreSQL, which uses the "postgres" superuser. The password is a fresh
+// random secret, not derived from instanceID: instanceID is the OSB URL
+// path segment and ends up in CF/k8s Service Catalog records and logs, so
+// it must never double as a credential.
+func adminCredentialsFor(serviceType string) (user string, passwd string, err error) {
+	passwd, err = generateAdminPasswd()
+	if err != nil {
+		return "", "", err
+	}
+	if serviceType == catalog.CatalogService_PostgreSQL {
+		return "postgres", passwd, nil
+	}
+	return "dbadmin", passwd, nil
+}
+
+// generateAdminPasswd returns a random, URL-safe admin password.
+func generateAdminPasswd() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Broker implements the Open Service Broker API on top of the existing
+// catalog operations (CatalogCreate*, CatalogCheckServiceInit, DeleteService,
+// ListServiceMember, GetConfigFile), so OSB consumers such as Cloud Foundry
+// or the Kubernetes Service Catalog can provision openmanage-backed
+// databases natively.
+type Broker struct {
+	cli     *client.ManageClient
+	region  string
+	cluster string
+
+	mu        sync.Mutex
+	instances map[string]*instanceState
+}
+
+// NewBroker creates a Broker that creates services in the given
+// region/cluster through cli.
+func NewBroker(cli *client.ManageClient, region string, cluster string) *Broker {
+	return &Broker{
+		cli:       cli,
+		region:    region,
+		cluster:   cluster,
+		instances: make(map[string]*instanceState),
+	}
+}
+
+// ServeHTTP routes the OSB v2 API paths to the matching handler.
+func (b *Broker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	segs := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/v2/catalog":
+		b.getCatalog(w, r)
+
+	case r.Method == http.MethodPut && len(segs) == 3 && segs[0] == "v2" && segs[1] == "service_instances":
+		b.provision(w, r, segs[2])
+
+	case r.Method == http.MethodDelete && len(segs) == 3 && segs[0] == "v2" && segs[1] == "service_instances":
+		b.deprovision(w, r, segs[2])
+
+	case r.Method == http.MethodPut && len(segs) == 5 && segs[0] == "v2" && segs[1] == "service_instances" && segs[3] == "service_bindings":
+		b.bind(w, r, segs[2], segs[4])
+
+	case r.Method == http.MethodGet && len(segs) == 4 && segs[0] == "v2" && segs[1] == "service_instances" && segs[3] == "last_operation":
+		b.lastOperation(w, r, segs[2])
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (b *Broker) getCatalog(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, CatalogResponse{Services: services})
+}
+
+func (b *Broker) provision(w http.ResponseWriter, r *http.Request, instanceID string) {
+	var req ProvisionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	plan, ok := findPlan(req.ServiceID, req.PlanID)
+	if !ok {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("unknown service_id %s or plan_id %s", req.ServiceID, req.PlanID))
+		return
+	}
+
+	b.mu.Lock()
+	if existing, ok := b.instances[instanceID]; ok && existing.state != stateFailed {
+		// OSB clients (Cloud Foundry, the Kubernetes Service Catalog)
+		// retry PUT /v2/service_instances/:id on timeout. Per spec, a
+		// retry with the same instance must be a no-op that returns the
+		// current state, not a second CatalogCreate*Service call.
+		b.mu.Unlock()
+		status := http.StatusAccepted
+		if existing.state == stateSucceeded {
+			status = http.StatusOK
+		}
+		writeJSON(w, status, ProvisionResponse{})
+		return
+	}
+
+	adminUser, adminPasswd, err := adminCredentialsFor(req.ServiceID)
+	if err != nil {
+		b.mu.Unlock()
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	b.instances[instanceID] = &instanceState{
+		serviceType: req.ServiceID,
+		state:       stateInProgress,
+		adminUser:   adminUser,
+		adminPasswd: adminPasswd,
+	}
+	b.mu.Unlock()
+
+	// provisioning happens asynchronously, the caller polls last_operation
+	// instead of blocking on the PUT, same as the existing
+	// DefaultServiceWaitSeconds wait loop used by the CLI.
+	go b.createAndWait(instanceID, req.ServiceID, plan, adminUser, adminPasswd)
+
+	writeJSON(w, http.StatusAccepted, ProvisionResponse{})
+}
+
+func (b *Broker) createAndWait(instanceID string, serviceType string, plan Plan, adminUser string, adminPasswd string) {
+	ctx := context.Background()
+
+	svcReq := &manage.ServiceCommonRequest{
+		Region:      b.region,
+		Cluster:     b.cluster,
+		ServiceName: instanceID,
+	}
+	res := &common.Resources{
+		MaxCPUUnits:     plan.CPUUnits,
+		ReserveCPUUnits: plan.CPUUnits,
+		MaxMemMB:        plan.MemoryMB,
+		ReserveMemMB:    plan.MemoryMB,
+	}
+
+	var err error
+	switch serviceType {
+	case catalog.CatalogService_MongoDB:
+		err = b.cli.CatalogCreateMongoDBService(ctx, &manage.CatalogCreateMongoDBRequest{
+			Service: svcReq, Resource: res, Replicas: plan.Replicas, VolumeSizeGB: plan.VolumeSizeGB,
+			Admin: adminUser, AdminPasswd: adminPasswd,
+		})
+	case catalog.CatalogService_PostgreSQL:
+		err = b.cli.CatalogCreatePostgreSQLService(ctx, &manage.CatalogCreatePostgreSQLRequest{
+			Service: svcReq, Resource: res, Replicas: plan.Replicas, VolumeSizeGB: plan.VolumeSizeGB,
+			Admin: adminUser, AdminPasswd: adminPasswd, ReplUser: "repluser", ReplUserPasswd: adminPasswd,
+		})
+	case catalog.CatalogService_Cassandra:
+		err = b.cli.CatalogCreateCassandraService(ctx, &manage.CatalogCreateCassandraRequest{
+			Service: svcReq, Resource: res, Replicas: plan.Replicas, VolumeSizeGB: plan.VolumeSizeGB,
+			Admin: adminUser, AdminPasswd: adminPasswd,
+		})
+	case catalog.CatalogService_Redis:
+		err = b.cli.CatalogCreateRedisService(ctx, &manage.CatalogCreateRedisRequest{
+			Service: svcReq, Resource: res, Replicas: plan.Replicas, VolumeSizeGB: plan.VolumeSizeGB,
+			Admin: adminUser, AdminPasswd: adminPasswd,
+		})
+	case catalog.CatalogService_ZooKeeper:
+		err = b.cli.CatalogCreateZooKeeperService(ctx, &manage.CatalogCreateZooKeeperRequest{
+			Service: svcReq, Resource: res, Replicas: plan.Replicas, VolumeSizeGB: plan.VolumeSizeGB,
+			Admin: adminUser, AdminPasswd: adminPasswd,
+		})
+	case catalog.CatalogService_Elasticsearch:
+		err = b.cli.CatalogCreateElasticsearchService(ctx, &manage.CatalogCreateElasticsearchRequest{
+			Service: svcReq, Resource: res, Replicas: plan.Replicas, VolumeSizeGB: plan.VolumeSizeGB,
+			Admin: adminUser, AdminPasswd: adminPasswd,
+		})
+	default:
+		err = fmt.Errorf("unsupported service type %s", serviceType)
+	}
+
+	if err != nil {
+		b.setState(instanceID, stateFailed, err.Error())
+		return
+	}
+
+	initReq := &manage.CatalogCheckServiceInitRequest{ServiceType: serviceType, Service: svcReq}
+	for sec := int64(0); sec < common.DefaultServiceWaitSeconds; sec += common.DefaultRetryWaitSeconds {
+		initialized, err := b.cli.CatalogCheckServiceInit(ctx, initReq)
+		if err == nil && initialized {
+			b.setState(instanceID, stateSucceeded, "")
+			return
+		}
+		time.Sleep(time.Duration(common.DefaultRetryWaitSeconds) * time.Second)
+	}
+
+	b.setState(instanceID, stateFailed, "service did not initialize in time")
+}
+
+func (b *Broker) setState(instanceID string, state string, description string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if inst, ok := b.instances[instanceID]; ok {
+		inst.state = state
+		inst.description = description
+	}
+}
+
+func (b *Broker) deprovision(w http.ResponseWriter, r *http.Request, instanceID string) {
+	svcReq := &manage.ServiceCommonRequest{
+		Region:      b.region,
+		Cluster:     b.cluster,
+		ServiceName: instanceID,
+	}
+
+	if err := b.cli.DeleteService(context.Background(), svcReq); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	b.mu.Lock()
+	delete(b.instances, instanceID)
+	b.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, struct{}{})
+}
+
+func (b *Broker) bind(w http.ResponseWriter, r *http.Request, instanceID string, bindingID string) {
+	ctx := context.Background()
+
+	b.mu.Lock()
+	inst, ok := b.instances[instanceID]
+	b.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("unknown instance %s", instanceID))
+		return
+	}
+
+	attr, err := b.cli.GetServiceAttr(ctx, &manage.ServiceCommonRequest{
+		Region:      b.region,
+		Cluster:     b.cluster,
+		ServiceName: instanceID,
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	members, err := b.cli.ListServiceMember(ctx, &manage.ListServiceMemberRequest{
+		Service: &manage.ServiceCommonRequest{Region: b.region, Cluster: b.cluster, ServiceName: instanceID},
+	})
+	if err != nil || len(members) == 0 {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("no members found for service %s", instanceID))
+		return
+	}
+
+	creds := instanceCredentials(inst)
+	for _, cfg := range members[0].Configs {
+		content, err := b.cli.GetConfigFile(ctx, &manage.GetConfigFileRequest{
+			Region:      b.region,
+			Cluster:     b.cluster,
+			ServiceUUID: attr.ServiceUUID,
+			FileID:      cfg.FileID,
+		})
+		if err != nil {
+			continue
+		}
+		switch {
+		case strings.HasSuffix(cfg.FileName, "ca.pem"):
+			creds.CACert = content.Content
+		case strings.HasSuffix(cfg.FileName, "cert.pem"):
+			creds.Cert = content.Content
+		case strings.HasSuffix(cfg.FileName, "key.pem"):
+			creds.Key = content.Content
+		}
+	}
+
+	writeJSON(w, http.StatusCreated, BindResponse{Credentials: creds})
+}
+
+// instanceCredentials builds the OSB bind credentials from the admin
+// user/password an instance was actually created with, tracked in
+// instanceState since provision time - never a value derived from the
+// instance id or any other caller-visible input.
+func instanceCredentials(inst *instanceState) Credentials {
+	return Credentials{Admin: inst.adminUser, AdminPasswd: inst.adminPasswd}
+}
+
+func (b *Broker) lastOperation(w http.ResponseWriter, r *http.Request, instanceID string) {
+	b.mu.Lock()
+	inst, ok := b.instances[instanceID]
+	b.mu.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("unknown instance %s", instanceID))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, LastOperationResponse{State: inst.state, Description: inst.description})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, ErrorResponse{Description: err.Error()})
+}
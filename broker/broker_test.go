@@ -0,0 +1,126 @@
+package broker
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cloudstax/openmanage/catalog"
+)
+
+func TestServeHTTPRouting(t *testing.T) {
+	b := NewBroker(nil, "us-west-1", "default")
+
+	// GET /v2/catalog never touches the ManageClient.
+	w := httptest.NewRecorder()
+	b.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v2/catalog", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("GET /v2/catalog: got status %d, want %d", w.Code, http.StatusOK)
+	}
+
+	// a bad JSON body on provision is rejected before the ManageClient is used.
+	w = httptest.NewRecorder()
+	b.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/v2/service_instances/my-db", strings.NewReader("not json")))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("PUT with bad body: got status %d, want %d", w.Code, http.StatusBadRequest)
+	}
+
+	// unknown paths 404.
+	w = httptest.NewRecorder()
+	b.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v2/unknown", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("GET /v2/unknown: got status %d, want %d", w.Code, http.StatusNotFound)
+	}
+
+	// last_operation for an unknown instance 404s without touching the
+	// ManageClient.
+	w = httptest.NewRecorder()
+	b.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v2/service_instances/no-such-id/last_operation", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("GET last_operation for unknown instance: got status %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
+
+// TestAdminCredentialsForGeneratesRandomSecret guards against the admin
+// password being the OSB instance id (or any other deterministic value):
+// two calls for the same service type must come back with different
+// passwords.
+func TestAdminCredentialsForGeneratesRandomSecret(t *testing.T) {
+	user1, passwd1, err := adminCredentialsFor(catalog.CatalogService_MongoDB)
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	user2, passwd2, err := adminCredentialsFor(catalog.CatalogService_MongoDB)
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+
+	if user1 != "dbadmin" || user2 != "dbadmin" {
+		t.Errorf("got users %q/%q, want dbadmin/dbadmin", user1, user2)
+	}
+	if passwd1 == "" || passwd2 == "" {
+		t.Errorf("got empty generated password")
+	}
+	if passwd1 == passwd2 {
+		t.Errorf("expected two calls to generate different passwords, got the same %q twice", passwd1)
+	}
+
+	pgUser, pgPasswd, err := adminCredentialsFor(catalog.CatalogService_PostgreSQL)
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if pgUser != "postgres" {
+		t.Errorf("got PostgreSQL admin user %q, want postgres", pgUser)
+	}
+	if pgPasswd == "" || pgPasswd == "my-db" {
+		t.Errorf("got PostgreSQL password %q, want a generated secret unrelated to any instance id", pgPasswd)
+	}
+}
+
+// TestProvisionRetryIsNoop simulates the OSB client-retry scenario
+// directly against the tracked instance map, since driving it through a
+// real PUT would require a live ManageClient: a retried provision for an
+// instance that is already in progress must leave the existing state (and
+// its already-generated admin password) untouched rather than starting a
+// second CatalogCreate*Service call.
+func TestProvisionRetryIsNoop(t *testing.T) {
+	b := NewBroker(nil, "us-west-1", "default")
+
+	existing := &instanceState{
+		serviceType: catalog.CatalogService_MongoDB,
+		state:       stateInProgress,
+		adminUser:   "dbadmin",
+		adminPasswd: "orig-secret",
+	}
+	b.instances["my-db"] = existing
+
+	body := `{"service_id":"` + catalog.CatalogService_MongoDB + `","plan_id":"` + catalog.CatalogService_MongoDB + `-small"}`
+	w := httptest.NewRecorder()
+	b.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/v2/service_instances/my-db", strings.NewReader(body)))
+
+	if w.Code != http.StatusAccepted {
+		t.Errorf("retry on in-progress instance: got status %d, want %d", w.Code, http.StatusAccepted)
+	}
+
+	b.mu.Lock()
+	got := b.instances["my-db"]
+	b.mu.Unlock()
+	if got != existing {
+		t.Errorf("retry replaced the tracked instance state instead of treating it as a no-op")
+	}
+	if got.adminPasswd != "orig-secret" {
+		t.Errorf("retry changed the tracked admin password to %q, want unchanged %q", got.adminPasswd, "orig-secret")
+	}
+}
+
+// TestInstanceCredentialsUsesTrackedState guards bind()'s credential
+// source: it must come from the instanceState an instance was actually
+// created with, not a value reconstructed from the instance id or plan.
+func TestInstanceCredentialsUsesTrackedState(t *testing.T) {
+	inst := &instanceState{adminUser: "dbadmin", adminPasswd: "s3cr3t"}
+	creds := instanceCredentials(inst)
+	if creds.Admin != "dbadmin" || creds.AdminPasswd != "s3cr3t" {
+		t.Errorf("got creds %+v, want Admin=dbadmin AdminPasswd=s3cr3t", creds)
+	}
+}
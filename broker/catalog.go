@@ -0,0 +1,64 @@
+package broker
+
+import "github.com/cloudstax/openmanage/catalog"
+
+// Plan encodes one OSB plan as a replicas/volume-size/cpu/memory preset for
+// a catalog service type.
+type Plan struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+
+	Replicas     int64 `json:"-"`
+	VolumeSizeGB int64 `json:"-"`
+	CPUUnits     int64 `json:"-"`
+	MemoryMB     int64 `json:"-"`
+}
+
+// Service is one catalog service type, exposed as an OSB "service" entry by
+// GET /v2/catalog.
+type Service struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Bindable    bool   `json:"bindable"`
+	Plans       []Plan `json:"plans"`
+}
+
+// CatalogResponse is the body of GET /v2/catalog.
+type CatalogResponse struct {
+	Services []Service `json:"services"`
+}
+
+func smallMediumLargePlans(unit string) []Plan {
+	return []Plan{
+		{ID: unit + "-small", Name: "small", Description: "1 replica, 10GB volume", Replicas: 1, VolumeSizeGB: 10, CPUUnits: 128, MemoryMB: 512},
+		{ID: unit + "-medium", Name: "medium", Description: "3 replicas, 50GB volume", Replicas: 3, VolumeSizeGB: 50, CPUUnits: 256, MemoryMB: 1024},
+		{ID: unit + "-large", Name: "large", Description: "5 replicas, 200GB volume", Replicas: 5, VolumeSizeGB: 200, CPUUnits: 512, MemoryMB: 2048},
+	}
+}
+
+// services lists every catalog service type this broker can provision,
+// along with the replicas/volume-size/cpu/memory presets each plan maps to.
+var services = []Service{
+	{ID: catalog.CatalogService_MongoDB, Name: catalog.CatalogService_MongoDB, Description: "MongoDB catalog service", Bindable: true, Plans: smallMediumLargePlans(catalog.CatalogService_MongoDB)},
+	{ID: catalog.CatalogService_PostgreSQL, Name: catalog.CatalogService_PostgreSQL, Description: "PostgreSQL catalog service", Bindable: true, Plans: smallMediumLargePlans(catalog.CatalogService_PostgreSQL)},
+	{ID: catalog.CatalogService_Cassandra, Name: catalog.CatalogService_Cassandra, Description: "Cassandra catalog service", Bindable: true, Plans: smallMediumLargePlans(catalog.CatalogService_Cassandra)},
+	{ID: catalog.CatalogService_Redis, Name: catalog.CatalogService_Redis, Description: "Redis catalog service", Bindable: true, Plans: smallMediumLargePlans(catalog.CatalogService_Redis)},
+	{ID: catalog.CatalogService_ZooKeeper, Name: catalog.CatalogService_ZooKeeper, Description: "ZooKeeper catalog service", Bindable: true, Plans: smallMediumLargePlans(catalog.CatalogService_ZooKeeper)},
+	{ID: catalog.CatalogService_Elasticsearch, Name: catalog.CatalogService_Elasticsearch, Description: "Elasticsearch catalog service", Bindable: true, Plans: smallMediumLargePlans(catalog.CatalogService_Elasticsearch)},
+}
+
+func findPlan(serviceID string, planID string) (Plan, bool) {
+	for _, svc := range services {
+		if svc.ID != serviceID {
+			continue
+		}
+		for _, plan := range svc.Plans {
+			if plan.ID == planID {
+				return plan, true
+			}
+		}
+	}
+	return Plan{}, false
+}
@@ -0,0 +1,25 @@
+package broker
+
+import (
+	"testing"
+
+	"github.com/cloudstax/openmanage/catalog"
+)
+
+func TestFindPlan(t *testing.T) {
+	plan, ok := findPlan(catalog.CatalogService_MongoDB, catalog.CatalogService_MongoDB+"-medium")
+	if !ok {
+		t.Fatalf("expected to find the mongodb medium plan")
+	}
+	if plan.Replicas != 3 || plan.VolumeSizeGB != 50 {
+		t.Errorf("unexpected plan %+v", plan)
+	}
+
+	if _, ok := findPlan(catalog.CatalogService_MongoDB, "no-such-plan"); ok {
+		t.Errorf("expected unknown plan id to not be found")
+	}
+
+	if _, ok := findPlan("no-such-service", catalog.CatalogService_MongoDB+"-medium"); ok {
+		t.Errorf("expected unknown service id to not be found")
+	}
+}
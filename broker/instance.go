@@ -0,0 +1,49 @@
+package broker
+
+// ProvisionRequest is the body of PUT /v2/service_instances/:id.
+type ProvisionRequest struct {
+	ServiceID string `json:"service_id"`
+	PlanID    string `json:"plan_id"`
+}
+
+// ProvisionResponse is the body of a successful provision response. The
+// dashboard_url field is omitted: there is no broker-hosted dashboard.
+type ProvisionResponse struct {
+	Operation string `json:"operation,omitempty"`
+}
+
+// BindRequest is the body of PUT /v2/service_instances/:id/service_bindings/:bid.
+type BindRequest struct {
+	ServiceID string `json:"service_id"`
+	PlanID    string `json:"plan_id"`
+}
+
+// Credentials is the "credentials" object of a BindResponse, built from the
+// admin user/password the broker tracked for the instance at provision time
+// and, if the service has them, its CA/cert/key config files.
+type Credentials struct {
+	Admin       string `json:"admin"`
+	AdminPasswd string `json:"password"`
+	CACert      string `json:"ca_cert,omitempty"`
+	Cert        string `json:"cert,omitempty"`
+	Key         string `json:"key,omitempty"`
+}
+
+// BindResponse is the body of a successful bind response.
+type BindResponse struct {
+	Credentials Credentials `json:"credentials"`
+}
+
+// LastOperationResponse is the body of GET
+// /v2/service_instances/:id/last_operation, mapped from the existing
+// CatalogCheckServiceInit polling loop.
+type LastOperationResponse struct {
+	State       string `json:"state"`
+	Description string `json:"description,omitempty"`
+}
+
+// ErrorResponse is returned for any OSB error, per the spec's generic error
+// schema.
+type ErrorResponse struct {
+	Description string `json:"description"`
+}
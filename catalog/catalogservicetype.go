@@ -0,0 +1,10 @@
+package catalog
+
+// Additional catalog service types, alongside CatalogService_MongoDB and
+// CatalogService_PostgreSQL.
+const (
+	CatalogService_Cassandra     = "cassandra"
+	CatalogService_Redis         = "redis"
+	CatalogService_ZooKeeper     = "zookeeper"
+	CatalogService_Elasticsearch = "elasticsearch"
+)
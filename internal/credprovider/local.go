@@ -0,0 +1,17 @@
+package credprovider
+
+import "io/ioutil"
+
+// LocalProvider reads credential material from the local filesystem, the
+// original (and still default) way to pass the ca/cert/key files.
+type LocalProvider struct{}
+
+// NewLocalProvider creates a LocalProvider.
+func NewLocalProvider() *LocalProvider {
+	return &LocalProvider{}
+}
+
+// Get reads uri as a local file path.
+func (p *LocalProvider) Get(uri string) ([]byte, error) {
+	return ioutil.ReadFile(uri)
+}
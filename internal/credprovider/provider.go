@@ -0,0 +1,40 @@
+package credprovider
+
+import "strings"
+
+const (
+	s3Scheme             = "s3://"
+	ssmScheme            = "ssm://"
+	secretsManagerScheme = "secretsmanager://"
+)
+
+// Provider fetches credential material (a CA/cert/key file's content)
+// addressed by a URI. A plain local path is handled by LocalProvider; the
+// s3://, ssm:// and secretsmanager:// schemes are handled by the
+// corresponding provider below.
+type Provider interface {
+	// Get returns the raw content addressed by uri.
+	Get(uri string) ([]byte, error)
+}
+
+// HasScheme returns whether uri uses one of the provider URI schemes,
+// rather than being a plain local file path.
+func HasScheme(uri string) bool {
+	return strings.HasPrefix(uri, s3Scheme) || strings.HasPrefix(uri, ssmScheme) || strings.HasPrefix(uri, secretsManagerScheme)
+}
+
+// NewProvider returns the Provider that can resolve uri: LocalProvider for
+// a plain path, or the S3/SSM/SecretsManager provider for a scheme-prefixed
+// URI.
+func NewProvider(region string, uri string) (Provider, error) {
+	switch {
+	case strings.HasPrefix(uri, s3Scheme):
+		return NewS3Provider(region)
+	case strings.HasPrefix(uri, ssmScheme):
+		return NewSSMProvider(region)
+	case strings.HasPrefix(uri, secretsManagerScheme):
+		return NewSecretsManagerProvider(region)
+	default:
+		return NewLocalProvider(), nil
+	}
+}
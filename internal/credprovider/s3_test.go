@@ -0,0 +1,25 @@
+package credprovider
+
+import "testing"
+
+func TestParseS3URI(t *testing.T) {
+	bucket, key, err := parseS3URI("s3://mybucket/certs/ca.pem")
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if bucket != "mybucket" || key != "certs/ca.pem" {
+		t.Errorf("got bucket %q key %q, want mybucket certs/ca.pem", bucket, key)
+	}
+
+	invalid := []string{
+		"s3://",
+		"s3://bucketonly",
+		"s3://bucketonly/",
+		"s3:///key",
+	}
+	for _, uri := range invalid {
+		if _, _, err := parseS3URI(uri); err == nil {
+			t.Errorf("parseS3URI(%q): expected error, got none", uri)
+		}
+	}
+}
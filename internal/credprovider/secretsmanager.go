@@ -0,0 +1,44 @@
+package credprovider
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+)
+
+// SecretsManagerProvider fetches credential material from an AWS Secrets
+// Manager secret.
+type SecretsManagerProvider struct {
+	svc *secretsmanager.SecretsManager
+}
+
+// NewSecretsManagerProvider creates a SecretsManagerProvider for the given
+// region.
+func NewSecretsManagerProvider(region string) (*SecretsManagerProvider, error) {
+	sess, err := session.NewSession(&aws.Config{Region: aws.String(region)})
+	if err != nil {
+		return nil, err
+	}
+	return &SecretsManagerProvider{svc: secretsmanager.New(sess)}, nil
+}
+
+// Get reads the secret addressed by a secretsmanager://arn URI. Binary
+// secrets are returned as-is; string secrets are returned as their raw
+// bytes.
+func (p *SecretsManagerProvider) Get(uri string) ([]byte, error) {
+	id := strings.TrimPrefix(uri, secretsManagerScheme)
+
+	resp, err := p.svc.GetSecretValue(&secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(id),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.SecretBinary != nil {
+		return resp.SecretBinary, nil
+	}
+	return []byte(aws.StringValue(resp.SecretString)), nil
+}
@@ -0,0 +1,40 @@
+package credprovider
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ssm"
+)
+
+// SSMProvider fetches credential material from an SSM Parameter Store
+// SecureString parameter.
+type SSMProvider struct {
+	svc *ssm.SSM
+}
+
+// NewSSMProvider creates an SSMProvider for the given region.
+func NewSSMProvider(region string) (*SSMProvider, error) {
+	sess, err := session.NewSession(&aws.Config{Region: aws.String(region)})
+	if err != nil {
+		return nil, err
+	}
+	return &SSMProvider{svc: ssm.New(sess)}, nil
+}
+
+// Get reads the parameter addressed by an ssm://parameter-name URI,
+// decrypting it if it is a SecureString.
+func (p *SSMProvider) Get(uri string) ([]byte, error) {
+	name := strings.TrimPrefix(uri, ssmScheme)
+
+	resp, err := p.svc.GetParameter(&ssm.GetParameterInput{
+		Name:           aws.String(name),
+		WithDecryption: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(aws.StringValue(resp.Parameter.Value)), nil
+}
@@ -0,0 +1,66 @@
+package manage
+
+import "github.com/cloudstax/openmanage/common"
+
+const (
+	// CatalogCreateCassandraOp creates a Cassandra catalog service.
+	CatalogCreateCassandraOp = "/catalogcreatecassandra"
+	// CatalogCreateRedisOp creates a Redis catalog service.
+	CatalogCreateRedisOp = "/catalogcreateredis"
+	// CatalogCreateZooKeeperOp creates a ZooKeeper catalog service.
+	CatalogCreateZooKeeperOp = "/catalogcreatezookeeper"
+	// CatalogCreateElasticsearchOp creates an Elasticsearch catalog service.
+	CatalogCreateElasticsearchOp = "/catalogcreateelasticsearch"
+)
+
+// CatalogCreateCassandraRequest creates a Cassandra catalog service.
+type CatalogCreateCassandraRequest struct {
+	Service      *ServiceCommonRequest
+	Resource     *common.Resources
+	Replicas     int64
+	VolumeSizeGB int64
+	Admin        string
+	AdminPasswd  string
+	// DataCenter is the Cassandra data center the replicas are created in,
+	// used when the service is part of a multi-DC cluster.
+	DataCenter string
+}
+
+// CatalogCreateRedisRequest creates a Redis catalog service. When
+// ClusterMode is set, the service is created as a Redis Cluster with the
+// given number of shards; otherwise it is created as a single master with
+// Sentinel-monitored replicas.
+type CatalogCreateRedisRequest struct {
+	Service      *ServiceCommonRequest
+	Resource     *common.Resources
+	Replicas     int64
+	VolumeSizeGB int64
+	Admin        string
+	AdminPasswd  string
+	ClusterMode  bool
+	Shards       int64
+}
+
+// CatalogCreateZooKeeperRequest creates a ZooKeeper catalog service.
+type CatalogCreateZooKeeperRequest struct {
+	Service      *ServiceCommonRequest
+	Resource     *common.Resources
+	Replicas     int64
+	VolumeSizeGB int64
+	Admin        string
+	AdminPasswd  string
+	// EnsemblePeerPort is the port ZooKeeper servers use to talk to each
+	// other within the ensemble.
+	EnsemblePeerPort int64
+}
+
+// CatalogCreateElasticsearchRequest creates an Elasticsearch catalog
+// service.
+type CatalogCreateElasticsearchRequest struct {
+	Service      *ServiceCommonRequest
+	Resource     *common.Resources
+	Replicas     int64
+	VolumeSizeGB int64
+	Admin        string
+	AdminPasswd  string
+}
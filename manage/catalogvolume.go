@@ -0,0 +1,55 @@
+package manage
+
+const (
+	// DeleteServiceVolumesOp deletes the EBS volumes that belonged to an
+	// already-deleted catalog service.
+	DeleteServiceVolumesOp = "/deleteservicevolumes"
+	// CatalogBackupServiceOp snapshots every member volume of a catalog
+	// service and persists the resulting manifest.
+	CatalogBackupServiceOp = "/catalogbackupservice"
+	// CatalogRestoreServiceOp recreates a catalog service from a backup
+	// manifest produced by CatalogBackupServiceOp.
+	CatalogRestoreServiceOp = "/catalogrestoreservice"
+)
+
+// CatalogDeleteServiceVolumesRequest requests the management service to
+// enumerate and delete every EBS volume that belonged to a deleted catalog
+// service. When DryRun is set, the volumes are only listed, not deleted.
+type CatalogDeleteServiceVolumesRequest struct {
+	Service *ServiceCommonRequest
+	DryRun  bool
+}
+
+// CatalogBackupServiceRequest requests a point-in-time snapshot backup of
+// every member volume of a catalog service.
+type CatalogBackupServiceRequest struct {
+	Service *ServiceCommonRequest
+}
+
+// BackupServiceMember captures the EBS snapshot taken for one member of a
+// service, so CatalogRestoreServiceRequest can recreate the member's volume
+// from it later.
+type BackupServiceMember struct {
+	MemberIndex      int64
+	VolumeID         string
+	SnapshotID       string
+	VolumeSizeGB     int64
+	AvailabilityZone string
+}
+
+// CatalogBackupServiceResponse is returned once every member volume has
+// been snapshotted. The same manifest is persisted as a config file through
+// the existing GetConfigFile/put path, keyed by BackupID.
+type CatalogBackupServiceResponse struct {
+	ServiceUUID string
+	BackupID    string
+	Members     []BackupServiceMember
+}
+
+// CatalogRestoreServiceRequest recreates a service from a backup manifest:
+// a new volume is created from each member's snapshot and attached to the
+// corresponding newly-created member.
+type CatalogRestoreServiceRequest struct {
+	Service  *ServiceCommonRequest
+	BackupID string
+}
@@ -0,0 +1,28 @@
+package client
+
+import (
+	"golang.org/x/net/context"
+
+	"github.com/cloudstax/openmanage/manage"
+)
+
+// CatalogCreateCassandraService creates a Cassandra catalog service.
+func (c *ManageClient) CatalogCreateCassandraService(ctx context.Context, req *manage.CatalogCreateCassandraRequest) error {
+	return c.sendRequest(ctx, manage.CatalogCreateCassandraOp, req, nil)
+}
+
+// CatalogCreateRedisService creates a Redis catalog service.
+func (c *ManageClient) CatalogCreateRedisService(ctx context.Context, req *manage.CatalogCreateRedisRequest) error {
+	return c.sendRequest(ctx, manage.CatalogCreateRedisOp, req, nil)
+}
+
+// CatalogCreateZooKeeperService creates a ZooKeeper catalog service.
+func (c *ManageClient) CatalogCreateZooKeeperService(ctx context.Context, req *manage.CatalogCreateZooKeeperRequest) error {
+	return c.sendRequest(ctx, manage.CatalogCreateZooKeeperOp, req, nil)
+}
+
+// CatalogCreateElasticsearchService creates an Elasticsearch catalog
+// service.
+func (c *ManageClient) CatalogCreateElasticsearchService(ctx context.Context, req *manage.CatalogCreateElasticsearchRequest) error {
+	return c.sendRequest(ctx, manage.CatalogCreateElasticsearchOp, req, nil)
+}
@@ -0,0 +1,32 @@
+package client
+
+import (
+	"golang.org/x/net/context"
+
+	"github.com/cloudstax/openmanage/manage"
+)
+
+// DeleteServiceVolumes tells the management service to enumerate and delete
+// every EBS volume that belonged to an already-deleted catalog service. When
+// req.DryRun is set, no volume is deleted. Only an error is returned; the
+// list of volumes is not returned to the caller.
+func (c *ManageClient) DeleteServiceVolumes(ctx context.Context, req *manage.CatalogDeleteServiceVolumesRequest) error {
+	return c.sendRequest(ctx, manage.DeleteServiceVolumesOp, req, nil)
+}
+
+// CatalogBackupService snapshots every member volume of a service and
+// returns the backup manifest.
+func (c *ManageClient) CatalogBackupService(ctx context.Context, req *manage.CatalogBackupServiceRequest) (*manage.CatalogBackupServiceResponse, error) {
+	resp := &manage.CatalogBackupServiceResponse{}
+	err := c.sendRequest(ctx, manage.CatalogBackupServiceOp, req, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// CatalogRestoreService recreates a service from a previously taken backup,
+// attaching a new volume created from each member's snapshot.
+func (c *ManageClient) CatalogRestoreService(ctx context.Context, req *manage.CatalogRestoreServiceRequest) error {
+	return c.sendRequest(ctx, manage.CatalogRestoreServiceOp, req, nil)
+}
@@ -0,0 +1,30 @@
+package client
+
+import (
+	"golang.org/x/net/context"
+
+	"github.com/cloudstax/openmanage/manage"
+)
+
+// ReconcileService scans a single catalog service for drift between its
+// member records and the live cluster/volume state, optionally repairing
+// what it finds.
+func (c *ManageClient) ReconcileService(ctx context.Context, req *manage.ReconcileServiceRequest) (*manage.ReconcileServiceResponse, error) {
+	resp := &manage.ReconcileServiceResponse{}
+	err := c.sendRequest(ctx, manage.ReconcileServiceOp, req, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ReconcileStatus returns the last ReconcileService run's results for a
+// service.
+func (c *ManageClient) ReconcileStatus(ctx context.Context, req *manage.ReconcileStatusRequest) (*manage.ReconcileServiceResponse, error) {
+	resp := &manage.ReconcileServiceResponse{}
+	err := c.sendRequest(ctx, manage.ReconcileStatusOp, req, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
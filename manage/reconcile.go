@@ -0,0 +1,60 @@
+package manage
+
+const (
+	// ReconcileServiceOp scans one catalog service for drift between its
+	// member records and the live cluster/volume state, and optionally
+	// repairs anything it finds.
+	ReconcileServiceOp = "/reconcileservice"
+	// ReconcileStatusOp returns the last ReconcileServiceOp run's results
+	// for a service.
+	ReconcileStatusOp = "/reconcilestatus"
+)
+
+// DriftKind classifies one piece of detected drift between the catalog
+// service's member records and the live cluster/volume state.
+type DriftKind string
+
+const (
+	// DriftMemberTaskDown means a member's task died while its volume is
+	// still detached; the task should be restarted and the same VolumeID
+	// reattached to preserve member identity.
+	DriftMemberTaskDown DriftKind = "member-task-down"
+	// DriftOrphanVolume means a volume is tagged with a known service-uuid
+	// but has no corresponding member record; it should be re-registered.
+	DriftOrphanVolume DriftKind = "orphan-volume"
+	// DriftOrphanTask means a task is running without a member record; it
+	// should be stopped.
+	DriftOrphanTask DriftKind = "orphan-task"
+)
+
+// DriftItem describes one piece of detected drift and, if repair was
+// requested, what was done about it.
+type DriftItem struct {
+	Kind        DriftKind
+	MemberIndex int64
+	VolumeID    string
+	Repaired    bool
+	Error       string
+}
+
+// ReconcileServiceRequest asks the management service to scan one catalog
+// service for drift. When Repair is false, drift is only reported, not
+// acted on.
+type ReconcileServiceRequest struct {
+	Service *ServiceCommonRequest
+	Repair  bool
+}
+
+// ReconcileServiceResponse reports what ReconcileServiceOp found, and
+// repaired, for one service. ReconcileStatusOp returns the same shape for
+// the last run.
+type ReconcileServiceResponse struct {
+	ServiceName string
+	Items       []DriftItem
+}
+
+// ReconcileStatusRequest asks for the last reconcile run's results for a
+// service.
+type ReconcileStatusRequest struct {
+	Service *ServiceCommonRequest
+}
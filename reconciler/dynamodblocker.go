@@ -0,0 +1,78 @@
+package reconciler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+)
+
+// lockAttrExpiresAt is the DynamoDB attribute a DynamoDBLocker uses to let
+// a held lock expire instead of wedging forever if its holder crashes
+// without calling Unlock.
+const lockAttrExpiresAt = "ExpiresAt"
+
+// DynamoDBLocker is a Locker backed by a DynamoDB table, safe to share
+// across multiple openmanage-reconciler instances in an HA deployment.
+// The table only needs a "ServiceName" (string) partition key; TryLock
+// uses a conditional PutItem so only one reconciler can hold a service's
+// lock at a time.
+type DynamoDBLocker struct {
+	svc       *dynamodb.DynamoDB
+	tableName string
+	ttl       time.Duration
+}
+
+// NewDynamoDBLocker creates a DynamoDBLocker against tableName in region.
+// A lock is considered stale, and reclaimable, ttl after it was acquired,
+// so a crashed reconciler cannot hold a service's lock forever.
+func NewDynamoDBLocker(region string, tableName string, ttl time.Duration) (*DynamoDBLocker, error) {
+	sess, err := session.NewSession(&aws.Config{Region: aws.String(region)})
+	if err != nil {
+		return nil, err
+	}
+	return &DynamoDBLocker{
+		svc:       dynamodb.New(sess),
+		tableName: tableName,
+		ttl:       ttl,
+	}, nil
+}
+
+// TryLock implements Locker.
+func (l *DynamoDBLocker) TryLock(serviceName string) (bool, error) {
+	now := time.Now().Unix()
+	expiresAt := now + int64(l.ttl.Seconds())
+
+	_, err := l.svc.PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String(l.tableName),
+		Item: map[string]*dynamodb.AttributeValue{
+			"ServiceName":     {S: aws.String(serviceName)},
+			lockAttrExpiresAt: {N: aws.String(fmt.Sprintf("%d", expiresAt))},
+		},
+		ConditionExpression: aws.String("attribute_not_exists(ServiceName) OR " + lockAttrExpiresAt + " < :now"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":now": {N: aws.String(fmt.Sprintf("%d", now))},
+		},
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// Unlock implements Locker.
+func (l *DynamoDBLocker) Unlock(serviceName string) error {
+	_, err := l.svc.DeleteItem(&dynamodb.DeleteItemInput{
+		TableName: aws.String(l.tableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"ServiceName": {S: aws.String(serviceName)},
+		},
+	})
+	return err
+}
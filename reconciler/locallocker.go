@@ -0,0 +1,38 @@
+package reconciler
+
+import "sync"
+
+// LocalLocker is an in-process Locker, only safe when a single
+// openmanage-reconciler instance is running. It exists as the default so
+// the daemon works out of the box; an HA deployment with multiple replicas
+// must supply a distributed Locker instead, such as DynamoDBLocker.
+type LocalLocker struct {
+	mu     sync.Mutex
+	locked map[string]bool
+}
+
+// NewLocalLocker creates a LocalLocker.
+func NewLocalLocker() *LocalLocker {
+	return &LocalLocker{locked: make(map[string]bool)}
+}
+
+// TryLock implements Locker.
+func (l *LocalLocker) TryLock(serviceName string) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.locked[serviceName] {
+		return false, nil
+	}
+	l.locked[serviceName] = true
+	return true, nil
+}
+
+// Unlock implements Locker.
+func (l *LocalLocker) Unlock(serviceName string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.locked, serviceName)
+	return nil
+}
@@ -0,0 +1,31 @@
+package reconciler
+
+import "testing"
+
+func TestLocalLocker(t *testing.T) {
+	l := NewLocalLocker()
+
+	locked, err := l.TryLock("svc1")
+	if err != nil || !locked {
+		t.Fatalf("expected first TryLock to succeed, got locked=%v err=%v", locked, err)
+	}
+
+	locked, err = l.TryLock("svc1")
+	if err != nil || locked {
+		t.Fatalf("expected second TryLock on the same service to fail, got locked=%v err=%v", locked, err)
+	}
+
+	locked, err = l.TryLock("svc2")
+	if err != nil || !locked {
+		t.Fatalf("expected TryLock on a different service to succeed, got locked=%v err=%v", locked, err)
+	}
+
+	if err := l.Unlock("svc1"); err != nil {
+		t.Fatalf("unexpected Unlock error %s", err)
+	}
+
+	locked, err = l.TryLock("svc1")
+	if err != nil || !locked {
+		t.Fatalf("expected TryLock after Unlock to succeed, got locked=%v err=%v", locked, err)
+	}
+}
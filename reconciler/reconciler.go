@@ -0,0 +1,118 @@
+package reconciler
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/cloudstax/openmanage/manage"
+	"github.com/cloudstax/openmanage/manage/client"
+)
+
+// Locker serializes reconciliation of a single service across concurrent
+// reconcilers, so an HA deployment of openmanage-reconciler daemons does
+// not double-act on the same service. LocalLocker is only correct for a
+// single daemon instance; an HA deployment should supply DynamoDBLocker,
+// or another Locker backed by shared storage, instead.
+type Locker interface {
+	// TryLock attempts to acquire the lock for serviceName, returning
+	// false if it is already held.
+	TryLock(serviceName string) (bool, error)
+	// Unlock releases a lock acquired by TryLock.
+	Unlock(serviceName string) error
+}
+
+// Reconciler periodically scans every service known to the management
+// service and asks it to reconcile each one, healing drift between the
+// catalog member records and the live cluster/volume state.
+type Reconciler struct {
+	cli     *client.ManageClient
+	region  string
+	cluster string
+	locker  Locker
+	repair  bool
+}
+
+// NewReconciler creates a Reconciler that reconciles every service in
+// region/cluster on Run's schedule. When repair is false, drift is only
+// reported, not acted on.
+func NewReconciler(cli *client.ManageClient, region string, cluster string, locker Locker, repair bool) *Reconciler {
+	return &Reconciler{
+		cli:     cli,
+		region:  region,
+		cluster: cluster,
+		locker:  locker,
+		repair:  repair,
+	}
+}
+
+// Run reconciles every service once per interval until ctx is done.
+func (r *Reconciler) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		r.runOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runOnce reconciles every service a single time.
+func (r *Reconciler) runOnce(ctx context.Context) {
+	services, err := r.cli.ListService(ctx, &manage.ListServiceRequest{
+		Region:  r.region,
+		Cluster: r.cluster,
+		Prefix:  "",
+	})
+	if err != nil {
+		fmt.Println("reconciler: ListService error", err)
+		return
+	}
+
+	for _, svc := range services {
+		r.reconcileOne(ctx, svc.ServiceName)
+	}
+}
+
+func (r *Reconciler) reconcileOne(ctx context.Context, serviceName string) {
+	locked, err := r.locker.TryLock(serviceName)
+	if err != nil {
+		fmt.Println("reconciler: TryLock error for service", serviceName, err)
+		return
+	}
+	if !locked {
+		// another reconciler is already working on this service.
+		return
+	}
+	defer r.locker.Unlock(serviceName)
+
+	req := &manage.ReconcileServiceRequest{
+		Service: &manage.ServiceCommonRequest{
+			Region:      r.region,
+			Cluster:     r.cluster,
+			ServiceName: serviceName,
+		},
+		Repair: r.repair,
+	}
+
+	resp, err := r.cli.ReconcileService(ctx, req)
+	if err != nil {
+		fmt.Println("reconciler: ReconcileService error for service", serviceName, err)
+		return
+	}
+
+	if len(resp.Items) == 0 {
+		return
+	}
+
+	fmt.Printf("reconciler: %d drift items for service %s:\n", len(resp.Items), serviceName)
+	for _, item := range resp.Items {
+		fmt.Printf("\t%+v\n", item)
+	}
+}
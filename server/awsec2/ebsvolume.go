@@ -0,0 +1,96 @@
+package awsec2
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/cloudstax/openmanage/common"
+)
+
+const (
+	deleteVolumeRetryCount    = 12
+	deleteVolumeRetryWaitTime = 5 * time.Second
+)
+
+// DeleteVolume deletes the given EBS volume. EBS may still report the
+// volume as "in-use" for a short time after the owning task is stopped, so
+// DeleteVolume retries on VolumeInUse with a fixed backoff before giving up.
+func DeleteVolume(sess *session.Session, volID string) error {
+	svc := ec2.New(sess)
+
+	var err error
+	for i := 0; i < deleteVolumeRetryCount; i++ {
+		_, err = svc.DeleteVolume(&ec2.DeleteVolumeInput{
+			VolumeId: aws.String(volID),
+		})
+		if err == nil {
+			return nil
+		}
+
+		if !isVolumeInUseError(err) {
+			return err
+		}
+
+		time.Sleep(deleteVolumeRetryWaitTime)
+	}
+
+	return err
+}
+
+func isVolumeInUseError(err error) bool {
+	// the ec2 api returns a VolumeInUse error code while the volume is
+	// still attached to a task that has not fully stopped yet.
+	aerr, ok := err.(awserr.Error)
+	return ok && aerr.Code() == "VolumeInUse"
+}
+
+// CreateSnapshot snapshots the given EBS volume and tags the snapshot with
+// the service uuid, member index and the time the snapshot was taken, so a
+// later restore can map snapshots back to members.
+func CreateSnapshot(sess *session.Session, volID string, serviceUUID string, memberIndex int64, timestamp string) (snapshotID string, err error) {
+	svc := ec2.New(sess)
+
+	resp, err := svc.CreateSnapshot(&ec2.CreateSnapshotInput{
+		VolumeId:    aws.String(volID),
+		Description: aws.String(fmt.Sprintf("openmanage backup of service %s member %d", serviceUUID, memberIndex)),
+		TagSpecifications: []*ec2.TagSpecification{
+			{
+				ResourceType: aws.String(ec2.ResourceTypeSnapshot),
+				Tags: []*ec2.Tag{
+					{Key: aws.String(common.AWSTagServiceUUID), Value: aws.String(serviceUUID)},
+					{Key: aws.String("member-index"), Value: aws.String(fmt.Sprintf("%d", memberIndex))},
+					{Key: aws.String("timestamp"), Value: aws.String(timestamp)},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return aws.StringValue(resp.SnapshotId), nil
+}
+
+// CreateVolumeFromSnapshot creates a new EBS volume of volSizeGB from the
+// given snapshot in the specified availability zone, for use when restoring
+// a service member from a backup.
+func CreateVolumeFromSnapshot(sess *session.Session, az string, snapshotID string, volSizeGB int64) (volID string, err error) {
+	svc := ec2.New(sess)
+
+	resp, err := svc.CreateVolume(&ec2.CreateVolumeInput{
+		AvailabilityZone: aws.String(az),
+		SnapshotId:       aws.String(snapshotID),
+		Size:             aws.Int64(volSizeGB),
+		VolumeType:       aws.String(ec2.VolumeTypeGp2),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return aws.StringValue(resp.VolumeId), nil
+}
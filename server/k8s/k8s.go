@@ -0,0 +1,222 @@
+package k8s
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/cloudstax/openmanage/common"
+)
+
+// K8sSvc implements the same container-orchestrator operations as
+// server/awsec2, backed by a Kubernetes cluster instead of ECS: catalog
+// services become StatefulSets with a PVC template, a headless Service for
+// stable member DNS, and a PodDisruptionBudget that allows one member down
+// at a time. The budget is the same for every replica count.
+type K8sSvc struct {
+	cliset    kubernetes.Interface
+	namespace string
+}
+
+// NewK8sSvc creates a K8sSvc from the given kubeconfig file. An empty
+// kubeconfig uses rest.InClusterConfig, for running inside the cluster it
+// manages.
+func NewK8sSvc(kubeconfig string, namespace string) (*K8sSvc, error) {
+	var config *rest.Config
+	var err error
+	if kubeconfig == "" {
+		config, err = rest.InClusterConfig()
+	} else {
+		config, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cliset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &K8sSvc{cliset: cliset, namespace: namespace}, nil
+}
+
+// CreateServiceRequest carries the parameters CreateService needs to create
+// a StatefulSet-backed catalog service, the k8s equivalent of what the ECS
+// path takes from a CatalogCreate*Request.
+type CreateServiceRequest struct {
+	ServiceName   string
+	Replicas      int64
+	VolumeSizeGB  int64
+	Resource      *common.Resources
+	StorageClass  string
+	Image         string
+	ContainerPort int32
+}
+
+// CreateService creates the headless Service, the StatefulSet with its PVC
+// template, and a PodDisruptionBudget with MaxUnavailable 1, whatever
+// Replicas is.
+func (k *K8sSvc) CreateService(req *CreateServiceRequest) error {
+	if err := k.createHeadlessService(req); err != nil {
+		return err
+	}
+	if err := k.createStatefulSet(req); err != nil {
+		return err
+	}
+	return k.createPodDisruptionBudget(req)
+}
+
+func (k *K8sSvc) createHeadlessService(req *CreateServiceRequest) error {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      req.ServiceName,
+			Namespace: k.namespace,
+			Labels:    map[string]string{"app": req.ServiceName},
+		},
+		Spec: corev1.ServiceSpec{
+			ClusterIP: corev1.ClusterIPNone,
+			Selector:  map[string]string{"app": req.ServiceName},
+			Ports: []corev1.ServicePort{
+				{Port: req.ContainerPort, TargetPort: intstr.FromInt(int(req.ContainerPort))},
+			},
+		},
+	}
+
+	_, err := k.cliset.CoreV1().Services(k.namespace).Create(svc)
+	return err
+}
+
+func (k *K8sSvc) createStatefulSet(req *CreateServiceRequest) error {
+	replicas := int32(req.Replicas)
+
+	// an empty StorageClassName pointer tells k8s to use no storage class
+	// at all, not "use the cluster default" - only set it when the
+	// operator actually asked for one.
+	var storageClassName *string
+	if req.StorageClass != "" {
+		storageClassName = &req.StorageClass
+	}
+
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      req.ServiceName,
+			Namespace: k.namespace,
+			Labels:    map[string]string{"app": req.ServiceName},
+		},
+		Spec: appsv1.StatefulSetSpec{
+			ServiceName: req.ServiceName,
+			Replicas:    &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": req.ServiceName},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"app": req.ServiceName},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  req.ServiceName,
+							Image: req.Image,
+							Ports: []corev1.ContainerPort{{ContainerPort: req.ContainerPort}},
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceCPU:    *resource.NewMilliQuantity(req.Resource.ReserveCPUUnits, resource.DecimalSI),
+									corev1.ResourceMemory: *resource.NewQuantity(req.Resource.ReserveMemMB*1024*1024, resource.BinarySI),
+								},
+								Limits: corev1.ResourceList{
+									corev1.ResourceCPU:    *resource.NewMilliQuantity(req.Resource.MaxCPUUnits, resource.DecimalSI),
+									corev1.ResourceMemory: *resource.NewQuantity(req.Resource.MaxMemMB*1024*1024, resource.BinarySI),
+								},
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{Name: "data", MountPath: "/data"},
+							},
+						},
+					},
+				},
+			},
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "data"},
+					Spec: corev1.PersistentVolumeClaimSpec{
+						AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+						StorageClassName: storageClassName,
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceStorage: *resource.NewQuantity(req.VolumeSizeGB*1024*1024*1024, resource.BinarySI),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	_, err := k.cliset.AppsV1().StatefulSets(k.namespace).Create(sts)
+	return err
+}
+
+func (k *K8sSvc) createPodDisruptionBudget(req *CreateServiceRequest) error {
+	// allow at most one member down at a time, so quorum-based services
+	// such as ZooKeeper or Cassandra stay available during node drains.
+	maxUnavailable := intstr.FromInt(1)
+
+	pdb := &policyv1beta1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      req.ServiceName,
+			Namespace: k.namespace,
+		},
+		Spec: policyv1beta1.PodDisruptionBudgetSpec{
+			MaxUnavailable: &maxUnavailable,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": req.ServiceName},
+			},
+		},
+	}
+
+	_, err := k.cliset.PolicyV1beta1().PodDisruptionBudgets(k.namespace).Create(pdb)
+	return err
+}
+
+// ListPods lists every pod that belongs to the given service's StatefulSet,
+// the k8s equivalent of ECS ListTasks.
+func (k *K8sSvc) ListPods(serviceName string) ([]corev1.Pod, error) {
+	list, err := k.cliset.CoreV1().Pods(k.namespace).List(metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("app=%s", serviceName),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// StopTask deletes the given pod, the k8s equivalent of ECS StopTask. The
+// StatefulSet controller recreates it and reattaches the same PVC, so
+// member identity is preserved.
+func (k *K8sSvc) StopTask(podName string) error {
+	return k.cliset.CoreV1().Pods(k.namespace).Delete(podName, &metav1.DeleteOptions{})
+}
+
+// DeleteService deletes the StatefulSet, headless Service and
+// PodDisruptionBudget for serviceName. The PVCs are left behind, mirroring
+// how ECS leaves the EBS volumes for the operator (or -delete-volumes) to
+// clean up.
+func (k *K8sSvc) DeleteService(serviceName string) error {
+	if err := k.cliset.AppsV1().StatefulSets(k.namespace).Delete(serviceName, &metav1.DeleteOptions{}); err != nil {
+		return err
+	}
+	if err := k.cliset.CoreV1().Services(k.namespace).Delete(serviceName, &metav1.DeleteOptions{}); err != nil {
+		return err
+	}
+	return k.cliset.PolicyV1beta1().PodDisruptionBudgets(k.namespace).Delete(serviceName, &metav1.DeleteOptions{})
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"crypto/tls"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/cloudstax/openmanage/broker"
+	"github.com/cloudstax/openmanage/dns"
+	"github.com/cloudstax/openmanage/manage/client"
+	"github.com/cloudstax/openmanage/server/awsec2"
+	"github.com/cloudstax/openmanage/utils"
+)
+
+// The Open Service Broker API front-end for the catalog service.
+
+var (
+	listenPort = flag.Int("port", 8080, "The port the broker listens on")
+	cluster    = flag.String("cluster", "default", "The ECS cluster")
+	serverURL  = flag.String("server-url", "", "the management service url, default: "+dns.GetDefaultManageServiceURL("cluster", false))
+	region     = flag.String("region", "", "The target AWS region")
+
+	tlsEnabled = flag.Bool("tls-enabled", false, "whether tls is enabled talking to the management service")
+	caFile     = flag.String("ca-file", "", "the ca file")
+	certFile   = flag.String("cert-file", "", "the cert file")
+	keyFile    = flag.String("key-file", "", "the key file")
+)
+
+func main() {
+	flag.Parse()
+
+	var err error
+	if *region == "" {
+		*region, err = awsec2.GetLocalEc2Region()
+		if err != nil {
+			fmt.Println("please specify the region")
+			os.Exit(-1)
+		}
+	}
+
+	var tlsConf *tls.Config
+	if *tlsEnabled {
+		tlsConf, err = utils.GenClientTLSConfig(*caFile, *certFile, *keyFile)
+		if err != nil {
+			fmt.Printf("GenClientTLSConfig error %s, ca file %s, cert file %s, key file %s\n", err, *caFile, *certFile, *keyFile)
+			os.Exit(-1)
+		}
+	}
+
+	url := *serverURL
+	if url == "" {
+		url = dns.GetDefaultManageServiceURL(*cluster, *tlsEnabled)
+	} else {
+		url = dns.FormatManageServiceURL(url, *tlsEnabled)
+	}
+
+	cli := client.NewManageClient(url, tlsConf)
+	b := broker.NewBroker(cli, *region, *cluster)
+
+	addr := fmt.Sprintf(":%d", *listenPort)
+	fmt.Println("openmanage-broker listening on", addr)
+	if err := http.ListenAndServe(addr, b); err != nil {
+		fmt.Println("ListenAndServe error", err)
+		os.Exit(-1)
+	}
+}
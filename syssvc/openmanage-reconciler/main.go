@@ -0,0 +1,84 @@
+package main
+
+import (
+	"crypto/tls"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/cloudstax/openmanage/dns"
+	"github.com/cloudstax/openmanage/manage/client"
+	"github.com/cloudstax/openmanage/reconciler"
+	"github.com/cloudstax/openmanage/server/awsec2"
+	"github.com/cloudstax/openmanage/utils"
+)
+
+// The long-running daemon that periodically reconciles every catalog
+// service against the live cluster/volume state.
+
+var (
+	cluster           = flag.String("cluster", "default", "The cluster name. Works for both the ECS and the k8s backend")
+	serverURL         = flag.String("server-url", "", "the management service url, default: "+dns.GetDefaultManageServiceURL("cluster", false))
+	region            = flag.String("region", "", "The target AWS region")
+	reconcileInterval = flag.Int64("reconcile-interval", 300, "How often to reconcile every service, unit: second")
+	repair            = flag.Bool("repair", true, "Whether to repair the drift found during reconciliation, instead of only reporting it")
+
+	lockTable = flag.String("lock-table", "", "The DynamoDB table used to serialize reconciliation across replicas. Required for an HA deployment with more than one openmanage-reconciler instance; leave empty to use the single-instance-only LocalLocker")
+	lockTTL   = flag.Int64("lock-ttl", 60, "How long a -lock-table lock is held before it is considered stale and reclaimable, unit: second")
+
+	tlsEnabled = flag.Bool("tls-enabled", false, "whether tls is enabled talking to the management service")
+	caFile     = flag.String("ca-file", "", "the ca file")
+	certFile   = flag.String("cert-file", "", "the cert file")
+	keyFile    = flag.String("key-file", "", "the key file")
+)
+
+func main() {
+	flag.Parse()
+
+	var err error
+	if *region == "" {
+		*region, err = awsec2.GetLocalEc2Region()
+		if err != nil {
+			fmt.Println("please specify the region")
+			os.Exit(-1)
+		}
+	}
+
+	var tlsConf *tls.Config
+	if *tlsEnabled {
+		tlsConf, err = utils.GenClientTLSConfigWithURI(*region, *caFile, *certFile, *keyFile)
+		if err != nil {
+			fmt.Printf("GenClientTLSConfig error %s, ca file %s, cert file %s, key file %s\n", err, *caFile, *certFile, *keyFile)
+			os.Exit(-1)
+		}
+	}
+
+	url := *serverURL
+	if url == "" {
+		url = dns.GetDefaultManageServiceURL(*cluster, *tlsEnabled)
+	} else {
+		url = dns.FormatManageServiceURL(url, *tlsEnabled)
+	}
+
+	var locker reconciler.Locker
+	if *lockTable != "" {
+		locker, err = reconciler.NewDynamoDBLocker(*region, *lockTable, time.Duration(*lockTTL)*time.Second)
+		if err != nil {
+			fmt.Printf("NewDynamoDBLocker error %s, lock table %s\n", err, *lockTable)
+			os.Exit(-1)
+		}
+	} else {
+		fmt.Println("openmanage-reconciler: -lock-table not set, using LocalLocker. This is only safe with a single openmanage-reconciler instance; an HA deployment must set -lock-table")
+		locker = reconciler.NewLocalLocker()
+	}
+
+	cli := client.NewManageClient(url, tlsConf)
+	r := reconciler.NewReconciler(cli, *region, *cluster, locker, *repair)
+
+	interval := time.Duration(*reconcileInterval) * time.Second
+	fmt.Printf("openmanage-reconciler started, cluster %s, reconcile every %s\n", *cluster, interval)
+	r.Run(context.Background(), interval)
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/cloudstax/openmanage/manage"
 	"github.com/cloudstax/openmanage/manage/client"
 	"github.com/cloudstax/openmanage/server/awsec2"
+	"github.com/cloudstax/openmanage/server/k8s"
 	"github.com/cloudstax/openmanage/utils"
 )
 
@@ -23,8 +24,8 @@ import (
 
 var (
 	op           = flag.String("op", "", "The operation type, such as create-service")
-	serviceType  = flag.String("service-type", "", "The catalog service type: mongodb|postgresql")
-	cluster      = flag.String("cluster", "default", "The ECS cluster")
+	serviceType  = flag.String("service-type", "", "The catalog service type: mongodb|postgresql|cassandra|redis|zookeeper|elasticsearch")
+	cluster      = flag.String("cluster", "default", "The cluster name. Works for both the ECS and the k8s backend")
 	serverURL    = flag.String("server-url", "", "the management service url, default: "+dns.GetDefaultManageServiceURL("cluster", false))
 	region       = flag.String("region", "", "The target AWS region")
 	service      = flag.String("service", "", "The target service name in ECS")
@@ -33,6 +34,12 @@ var (
 	cpuUnits     = flag.Int64("cpu-units", common.DefaultReserveCPUUnits, "The number of cpu units to reserve for the container")
 	reserveMemMB = flag.Int64("soft-memory", common.DefaultReserveMemoryMB, "The memory reserved for the container, unit: MB")
 
+	// k8s backend parameters. Leave -kubeconfig unset to keep targeting the
+	// ECS backend through server-url as before.
+	kubeconfig   = flag.String("kubeconfig", "", "The kubeconfig file for the k8s backend. Empty keeps the ECS backend")
+	k8sNamespace = flag.String("namespace", "default", "The k8s namespace given to the k8s client built from -kubeconfig, only used with -kubeconfig. Not passed on to the create requests")
+	storageClass = flag.String("storage-class", "", "The k8s StorageClass for catalog service volumes. Not passed on to the create requests")
+
 	// security parameters
 	admin       = flag.String("admin", "dbadmin", "The DB admin. For PostgreSQL, use default user \"postgres\"")
 	adminPasswd = flag.String("passwd", "changeme", "The DB admin password")
@@ -45,28 +52,52 @@ var (
 	replUser       = flag.String("replication-user", "repluser", "The replication user that the standby DB replicates from the primary")
 	replUserPasswd = flag.String("replication-passwd", "replpassword", "The password for the standby DB to access the primary")
 
+	// The cassandra service creation specific parameters.
+	cassandraDC = flag.String("cassandra-dc", "", "The Cassandra data center the replicas are created in")
+
+	// The redis service creation specific parameters.
+	redisClusterMode = flag.Bool("redis-cluster-mode", false, "Whether to create the Redis service as a Redis Cluster")
+	redisShards      = flag.Int64("redis-shards", 1, "The number of shards, only applies when -redis-cluster-mode is set")
+
+	// The zookeeper service creation specific parameters.
+	zkEnsemblePeerPort = flag.Int64("zk-ensemble-peer-port", 2888, "The port ZooKeeper servers use to talk to each other within the ensemble")
+
 	// the parameters for getting the config file
 	serviceUUID = flag.String("service-uuid", "", "The service uuid")
 	fileID      = flag.String("fileid", "", "The config file id")
+
+	// the parameters for deleting the service EBS volumes
+	deleteVolumes = flag.Bool("delete-volumes", false, "Whether to delete the service's EBS volumes")
+	dryRun        = flag.Bool("dry-run", false, "With -delete-volumes, only print the EBS volumes that would be deleted instead of deleting them. The service itself is still deleted")
+
+	// the parameters for backup-service/restore-service
+	backupID = flag.String("backup-id", "", "The backup id to restore from")
+
+	// the parameters for reconcile/reconcile-status
+	repair = flag.Bool("repair", false, "Whether to repair the drift found by -op=reconcile, instead of only reporting it")
 )
 
 const (
 	defaultPGAdmin = "postgres"
 
-	opCreate      = "create-service"
-	opCheckInit   = "check-service-init"
-	opDelete      = "delete-service"
-	opList        = "list-services"
-	opGet         = "get-service"
-	opListMembers = "list-members"
-	opGetConfig   = "get-config"
+	opCreate          = "create-service"
+	opCheckInit       = "check-service-init"
+	opDelete          = "delete-service"
+	opList            = "list-services"
+	opGet             = "get-service"
+	opListMembers     = "list-members"
+	opGetConfig       = "get-config"
+	opBackup          = "backup-service"
+	opRestore         = "restore-service"
+	opReconcile       = "reconcile"
+	opReconcileStatus = "reconcile-status"
 )
 
 func usage() {
 	flag.Usage = func() {
 		switch *op {
 		case opCreate:
-			fmt.Printf("usage: openmanage-catalogservice-cli -op=%s -service-type=<mongodb|postgres> [OPTIONS]\n", opCreate)
+			fmt.Printf("usage: openmanage-catalogservice-cli -op=%s -service-type=<mongodb|postgresql|cassandra|redis|zookeeper|elasticsearch> [OPTIONS]\n", opCreate)
 			flag.PrintDefaults()
 		case opCheckInit:
 			fmt.Printf("usage: openmanage-catalogservice-cli -op=%s -region=us-west-1 -cluster=default -service=aaa -admin=admin -passwd=passwd\n", opCheckInit)
@@ -80,9 +111,17 @@ func usage() {
 			fmt.Printf("usage: openmanage-catalogservice-cli -op=%s -region=us-west-1 -cluster=default -service=aaa\n", opListMembers)
 		case opGetConfig:
 			fmt.Printf("usage: openmanage-catalogservice-cli -op=%s -region=us-west-1 -cluster=default -service-uuid=auuid -fileid=configfileID\n", opGetConfig)
+		case opBackup:
+			fmt.Printf("usage: openmanage-catalogservice-cli -op=%s -region=us-west-1 -cluster=default -service=aaa\n", opBackup)
+		case opRestore:
+			fmt.Printf("usage: openmanage-catalogservice-cli -op=%s -region=us-west-1 -cluster=default -service=aaa -backup-id=abackupid\n", opRestore)
+		case opReconcile:
+			fmt.Printf("usage: openmanage-catalogservice-cli -op=%s -region=us-west-1 -cluster=default -service=aaa [-repair]\n", opReconcile)
+		case opReconcileStatus:
+			fmt.Printf("usage: openmanage-catalogservice-cli -op=%s -region=us-west-1 -cluster=default -service=aaa\n", opReconcileStatus)
 		default:
-			fmt.Printf("usage: openmanage-catalogservice-cli -op=<%s|%s|%s|%s|%s|%s> --help",
-				opCreate, opCheckInit, opDelete, opList, opGet, opListMembers)
+			fmt.Printf("usage: openmanage-catalogservice-cli -op=<%s|%s|%s|%s|%s|%s|%s|%s|%s|%s> --help",
+				opCreate, opCheckInit, opDelete, opList, opGet, opListMembers, opBackup, opRestore, opReconcile, opReconcileStatus)
 		}
 	}
 }
@@ -111,14 +150,30 @@ func main() {
 
 	var tlsConf *tls.Config
 	if *tlsEnabled {
-		// TODO how to pass the ca/cert/key files to container? one option is: store them in S3.
-		tlsConf, err = utils.GenClientTLSConfig(*caFile, *certFile, *keyFile)
+		// caFile/certFile/keyFile may be local paths, or s3://, ssm:// or
+		// secretsmanager:// URIs resolved through internal/credprovider.
+		tlsConf, err = utils.GenClientTLSConfigWithURI(*region, *caFile, *certFile, *keyFile)
 		if err != nil {
 			fmt.Printf("GenClientTLSConfig error %s, ca file %s, cert file %s, key file %s\n", err, *caFile, *certFile, *keyFile)
 			os.Exit(-1)
 		}
 	}
 
+	if *kubeconfig != "" {
+		// The manage service itself owns translating catalog requests into
+		// StatefulSets via server/k8s; this tool only has the HTTP surface
+		// to that service (manage/client), not the service's own source. So
+		// the CLI does not route CatalogCreate*Service through server/k8s
+		// here. It only checks that -kubeconfig loads into a k8s client
+		// config, catching an unreadable or malformed kubeconfig early
+		// instead of failing deep inside an async create-service call. The
+		// cluster is not contacted and -namespace is not checked.
+		if _, err := k8s.NewK8sSvc(*kubeconfig, *k8sNamespace); err != nil {
+			fmt.Printf("k8s.NewK8sSvc error %s, kubeconfig %s, namespace %s\n", err, *kubeconfig, *k8sNamespace)
+			os.Exit(-1)
+		}
+	}
+
 	if *serverURL == "" {
 		// use default server url
 		*serverURL = dns.GetDefaultManageServiceURL(*cluster, *tlsEnabled)
@@ -138,9 +193,19 @@ func main() {
 			createMongoDBService(ctx, cli)
 		case catalog.CatalogService_PostgreSQL:
 			createPostgreSQLService(ctx, cli)
+		case catalog.CatalogService_Cassandra:
+			createCassandraService(ctx, cli)
+		case catalog.CatalogService_Redis:
+			createRedisService(ctx, cli)
+		case catalog.CatalogService_ZooKeeper:
+			createZooKeeperService(ctx, cli)
+		case catalog.CatalogService_Elasticsearch:
+			createElasticsearchService(ctx, cli)
 		default:
-			fmt.Printf("Invalid service type, please specify %s|%s\n",
-				catalog.CatalogService_MongoDB, catalog.CatalogService_PostgreSQL)
+			fmt.Printf("Invalid service type, please specify %s|%s|%s|%s|%s|%s\n",
+				catalog.CatalogService_MongoDB, catalog.CatalogService_PostgreSQL,
+				catalog.CatalogService_Cassandra, catalog.CatalogService_Redis,
+				catalog.CatalogService_ZooKeeper, catalog.CatalogService_Elasticsearch)
 			os.Exit(-1)
 		}
 
@@ -169,9 +234,21 @@ func main() {
 	case opGetConfig:
 		getConfig(ctx, cli)
 
+	case opBackup:
+		backupService(ctx, cli)
+
+	case opRestore:
+		restoreService(ctx, cli)
+
+	case opReconcile:
+		reconcileService(ctx, cli)
+
+	case opReconcileStatus:
+		reconcileStatus(ctx, cli)
+
 	default:
-		fmt.Printf("Invalid operation, please specify %s|%s|%s|%s|%s|%s\n",
-			opCreate, opCheckInit, opDelete, opList, opGet, opListMembers)
+		fmt.Printf("Invalid operation, please specify %s|%s|%s|%s|%s|%s|%s|%s|%s|%s\n",
+			opCreate, opCheckInit, opDelete, opList, opGet, opListMembers, opBackup, opRestore, opReconcile, opReconcileStatus)
 		os.Exit(-1)
 	}
 }
@@ -215,6 +292,12 @@ func createMongoDBService(ctx context.Context, cli *client.ManageClient) {
 		AdminPasswd: *adminPasswd,
 	}
 
+	waitServiceInit(ctx, cli, initReq)
+}
+
+// waitServiceInit polls CatalogCheckServiceInit until the service reports
+// initialized, or exits the process after common.DefaultServiceWaitSeconds.
+func waitServiceInit(ctx context.Context, cli *client.ManageClient, initReq *manage.CatalogCheckServiceInitRequest) {
 	sleepSeconds := time.Duration(10) * time.Second
 	for sec := int64(0); sec < common.DefaultServiceWaitSeconds; sec += common.DefaultRetryWaitSeconds {
 		initialized, err := cli.CatalogCheckServiceInit(ctx, initReq)
@@ -266,6 +349,178 @@ func createPostgreSQLService(ctx context.Context, cli *client.ManageClient) {
 	fmt.Println("The postgresql service is created")
 }
 
+func createCassandraService(ctx context.Context, cli *client.ManageClient) {
+	if *replicas == 0 || *volSizeGB == 0 {
+		fmt.Println("please specify the valid replica number and volume size")
+		os.Exit(-1)
+	}
+
+	req := &manage.CatalogCreateCassandraRequest{
+		Service: &manage.ServiceCommonRequest{
+			Region:      *region,
+			Cluster:     *cluster,
+			ServiceName: *service,
+		},
+		Resource: &common.Resources{
+			MaxCPUUnits:     *cpuUnits,
+			ReserveCPUUnits: *cpuUnits,
+			MaxMemMB:        *reserveMemMB,
+			ReserveMemMB:    *reserveMemMB,
+		},
+		Replicas:     *replicas,
+		VolumeSizeGB: *volSizeGB,
+		Admin:        *admin,
+		AdminPasswd:  *adminPasswd,
+		DataCenter:   *cassandraDC,
+	}
+
+	err := cli.CatalogCreateCassandraService(ctx, req)
+	if err != nil {
+		fmt.Println("create catalog cassandra service error", err)
+		os.Exit(-1)
+	}
+
+	fmt.Println("The catalog service is created, wait till it gets initialized")
+
+	initReq := &manage.CatalogCheckServiceInitRequest{
+		ServiceType: catalog.CatalogService_Cassandra,
+		Service:     req.Service,
+		Admin:       *admin,
+		AdminPasswd: *adminPasswd,
+	}
+
+	waitServiceInit(ctx, cli, initReq)
+}
+
+func createRedisService(ctx context.Context, cli *client.ManageClient) {
+	if *replicas == 0 || *volSizeGB == 0 {
+		fmt.Println("please specify the valid replica number and volume size")
+		os.Exit(-1)
+	}
+
+	req := &manage.CatalogCreateRedisRequest{
+		Service: &manage.ServiceCommonRequest{
+			Region:      *region,
+			Cluster:     *cluster,
+			ServiceName: *service,
+		},
+		Resource: &common.Resources{
+			MaxCPUUnits:     *cpuUnits,
+			ReserveCPUUnits: *cpuUnits,
+			MaxMemMB:        *reserveMemMB,
+			ReserveMemMB:    *reserveMemMB,
+		},
+		Replicas:     *replicas,
+		VolumeSizeGB: *volSizeGB,
+		Admin:        *admin,
+		AdminPasswd:  *adminPasswd,
+		ClusterMode:  *redisClusterMode,
+		Shards:       *redisShards,
+	}
+
+	err := cli.CatalogCreateRedisService(ctx, req)
+	if err != nil {
+		fmt.Println("create catalog redis service error", err)
+		os.Exit(-1)
+	}
+
+	fmt.Println("The catalog service is created, wait till it gets initialized")
+
+	initReq := &manage.CatalogCheckServiceInitRequest{
+		ServiceType: catalog.CatalogService_Redis,
+		Service:     req.Service,
+		Admin:       *admin,
+		AdminPasswd: *adminPasswd,
+	}
+
+	waitServiceInit(ctx, cli, initReq)
+}
+
+func createZooKeeperService(ctx context.Context, cli *client.ManageClient) {
+	if *replicas == 0 || *volSizeGB == 0 {
+		fmt.Println("please specify the valid replica number and volume size")
+		os.Exit(-1)
+	}
+
+	req := &manage.CatalogCreateZooKeeperRequest{
+		Service: &manage.ServiceCommonRequest{
+			Region:      *region,
+			Cluster:     *cluster,
+			ServiceName: *service,
+		},
+		Resource: &common.Resources{
+			MaxCPUUnits:     *cpuUnits,
+			ReserveCPUUnits: *cpuUnits,
+			MaxMemMB:        *reserveMemMB,
+			ReserveMemMB:    *reserveMemMB,
+		},
+		Replicas:         *replicas,
+		VolumeSizeGB:     *volSizeGB,
+		Admin:            *admin,
+		AdminPasswd:      *adminPasswd,
+		EnsemblePeerPort: *zkEnsemblePeerPort,
+	}
+
+	err := cli.CatalogCreateZooKeeperService(ctx, req)
+	if err != nil {
+		fmt.Println("create catalog zookeeper service error", err)
+		os.Exit(-1)
+	}
+
+	fmt.Println("The catalog service is created, wait till it gets initialized")
+
+	initReq := &manage.CatalogCheckServiceInitRequest{
+		ServiceType: catalog.CatalogService_ZooKeeper,
+		Service:     req.Service,
+		Admin:       *admin,
+		AdminPasswd: *adminPasswd,
+	}
+
+	waitServiceInit(ctx, cli, initReq)
+}
+
+func createElasticsearchService(ctx context.Context, cli *client.ManageClient) {
+	if *replicas == 0 || *volSizeGB == 0 {
+		fmt.Println("please specify the valid replica number and volume size")
+		os.Exit(-1)
+	}
+
+	req := &manage.CatalogCreateElasticsearchRequest{
+		Service: &manage.ServiceCommonRequest{
+			Region:      *region,
+			Cluster:     *cluster,
+			ServiceName: *service,
+		},
+		Resource: &common.Resources{
+			MaxCPUUnits:     *cpuUnits,
+			ReserveCPUUnits: *cpuUnits,
+			MaxMemMB:        *reserveMemMB,
+			ReserveMemMB:    *reserveMemMB,
+		},
+		Replicas:     *replicas,
+		VolumeSizeGB: *volSizeGB,
+		Admin:        *admin,
+		AdminPasswd:  *adminPasswd,
+	}
+
+	err := cli.CatalogCreateElasticsearchService(ctx, req)
+	if err != nil {
+		fmt.Println("create catalog elasticsearch service error", err)
+		os.Exit(-1)
+	}
+
+	fmt.Println("The catalog service is created, wait till it gets initialized")
+
+	initReq := &manage.CatalogCheckServiceInitRequest{
+		ServiceType: catalog.CatalogService_Elasticsearch,
+		Service:     req.Service,
+		Admin:       *admin,
+		AdminPasswd: *adminPasswd,
+	}
+
+	waitServiceInit(ctx, cli, initReq)
+}
+
 func checkServiceInit(ctx context.Context, cli *client.ManageClient) {
 	req := &manage.CatalogCheckServiceInitRequest{
 		ServiceType: *serviceType,
@@ -369,7 +624,69 @@ func deleteService(ctx context.Context, cli *client.ManageClient) {
 		os.Exit(-1)
 	}
 
-	fmt.Println("Service deleted, please manually delete the EBS volumes\n\t", volIDs)
+	if !*deleteVolumes {
+		fmt.Println("Service deleted, please manually delete the EBS volumes\n\t", volIDs)
+		return
+	}
+
+	delReq := &manage.CatalogDeleteServiceVolumesRequest{
+		Service: serviceReq,
+		DryRun:  *dryRun,
+	}
+
+	err = cli.DeleteServiceVolumes(ctx, delReq)
+	if err != nil {
+		fmt.Println("DeleteServiceVolumes error", err)
+		os.Exit(-1)
+	}
+
+	if *dryRun {
+		fmt.Println("Service deleted, the following EBS volumes would be deleted\n\t", volIDs)
+	} else {
+		fmt.Println("Service deleted, the EBS volumes are deleted\n\t", volIDs)
+	}
+}
+
+func backupService(ctx context.Context, cli *client.ManageClient) {
+	req := &manage.CatalogBackupServiceRequest{
+		Service: &manage.ServiceCommonRequest{
+			Region:      *region,
+			Cluster:     *cluster,
+			ServiceName: *service,
+		},
+	}
+
+	resp, err := cli.CatalogBackupService(ctx, req)
+	if err != nil {
+		fmt.Println("CatalogBackupService error", err)
+		os.Exit(-1)
+	}
+
+	fmt.Printf("Service backed up, backup-id %s, %d member snapshots taken\n", resp.BackupID, len(resp.Members))
+}
+
+func restoreService(ctx context.Context, cli *client.ManageClient) {
+	if *backupID == "" {
+		fmt.Println("please specify the backup id to restore from")
+		os.Exit(-1)
+	}
+
+	req := &manage.CatalogRestoreServiceRequest{
+		Service: &manage.ServiceCommonRequest{
+			Region:      *region,
+			Cluster:     *cluster,
+			ServiceName: *service,
+		},
+		BackupID: *backupID,
+	}
+
+	err := cli.CatalogRestoreService(ctx, req)
+	if err != nil {
+		fmt.Println("CatalogRestoreService error", err)
+		os.Exit(-1)
+	}
+
+	fmt.Println("Service restored from backup", *backupID)
 }
 
 func getConfig(ctx context.Context, cli *client.ManageClient) {
@@ -392,4 +709,53 @@ func getConfig(ctx context.Context, cli *client.ManageClient) {
 	}
 
 	fmt.Println("%+v\n", *cfg)
-}
\ No newline at end of file
+}
+
+func reconcileService(ctx context.Context, cli *client.ManageClient) {
+	req := &manage.ReconcileServiceRequest{
+		Service: &manage.ServiceCommonRequest{
+			Region:      *region,
+			Cluster:     *cluster,
+			ServiceName: *service,
+		},
+		Repair: *repair,
+	}
+
+	resp, err := cli.ReconcileService(ctx, req)
+	if err != nil {
+		fmt.Println("ReconcileService error", err)
+		os.Exit(-1)
+	}
+
+	printDriftItems(resp)
+}
+
+func reconcileStatus(ctx context.Context, cli *client.ManageClient) {
+	req := &manage.ReconcileStatusRequest{
+		Service: &manage.ServiceCommonRequest{
+			Region:      *region,
+			Cluster:     *cluster,
+			ServiceName: *service,
+		},
+	}
+
+	resp, err := cli.ReconcileStatus(ctx, req)
+	if err != nil {
+		fmt.Println("ReconcileStatus error", err)
+		os.Exit(-1)
+	}
+
+	printDriftItems(resp)
+}
+
+func printDriftItems(resp *manage.ReconcileServiceResponse) {
+	if len(resp.Items) == 0 {
+		fmt.Println("No drift detected for service", resp.ServiceName)
+		return
+	}
+
+	fmt.Printf("%d drift items for service %s:\n", len(resp.Items), resp.ServiceName)
+	for _, item := range resp.Items {
+		fmt.Printf("\t%+v\n", item)
+	}
+}
@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"crypto/tls"
+	"io/ioutil"
+	"os"
+
+	"github.com/cloudstax/openmanage/internal/credprovider"
+)
+
+// GenClientTLSConfigWithURI is like GenClientTLSConfig, except caFile,
+// certFile and keyFile may additionally be s3://, ssm:// or
+// secretsmanager:// URIs, resolved through internal/credprovider. This lets
+// operators rotate certs centrally instead of baking them into container
+// images or mounting host paths.
+func GenClientTLSConfigWithURI(region string, caFile string, certFile string, keyFile string) (*tls.Config, error) {
+	ca, caIsTemp, err := resolveCredFile(region, caFile)
+	if err != nil {
+		return nil, err
+	}
+	if caIsTemp {
+		defer os.Remove(ca)
+	}
+
+	cert, certIsTemp, err := resolveCredFile(region, certFile)
+	if err != nil {
+		return nil, err
+	}
+	if certIsTemp {
+		defer os.Remove(cert)
+	}
+
+	key, keyIsTemp, err := resolveCredFile(region, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	if keyIsTemp {
+		defer os.Remove(key)
+	}
+
+	return GenClientTLSConfig(ca, cert, key)
+}
+
+// resolveCredFile returns a local path that holds uri's content, and
+// whether that path is a temp file the caller must remove when done. uri
+// itself is returned unchanged (isTemp false) when it is already a local
+// path, so callers never delete a real file an operator passed directly;
+// only content fetched and written out for a credprovider scheme prefix
+// is temporary.
+func resolveCredFile(region string, uri string) (path string, isTemp bool, err error) {
+	if !credprovider.HasScheme(uri) {
+		return uri, false, nil
+	}
+
+	provider, err := credprovider.NewProvider(region, uri)
+	if err != nil {
+		return "", false, err
+	}
+
+	content, err := provider.Get(uri)
+	if err != nil {
+		return "", false, err
+	}
+
+	f, err := ioutil.TempFile("", "openmanage-cred-")
+	if err != nil {
+		return "", false, err
+	}
+	defer f.Close()
+
+	if _, err = f.Write(content); err != nil {
+		os.Remove(f.Name())
+		return "", false, err
+	}
+
+	return f.Name(), true, nil
+}